@@ -0,0 +1,51 @@
+package remarshal
+
+import "fmt"
+
+// Format is a pluggable serialization format usable with Convert and the
+// streaming Decoder/Encoder. The built-in formats (TOML, YAML, JSON, JSONC,
+// HCL, XML, CSV, TSV) are registered in init; third parties can add support
+// for other formats by implementing Format and calling RegisterFormat.
+type Format interface {
+	// Name is the format's canonical name, e.g. "JSON". It is what callers
+	// pass as inputF/outputF to Convert and as the format argument to
+	// NewDecoder/NewEncoder.
+	Name() string
+
+	// Unmarshal decodes serialized data into a structure of nested maps and
+	// slices.
+	Unmarshal(data []byte) (interface{}, error)
+
+	// Marshal encodes data stored in nested maps and slices.
+	Marshal(data interface{}) ([]byte, error)
+}
+
+var registry = map[string]Format{}
+
+// RegisterFormat makes f available to Convert, NewDecoder and NewEncoder
+// under f.Name(). Registering a name a second time replaces the Format
+// previously registered under it, so callers can also use RegisterFormat to
+// override a built-in format.
+func RegisterFormat(f Format) {
+	registry[f.Name()] = f
+}
+
+// lookupFormat returns the Format registered under name.
+func lookupFormat(name string) (Format, error) {
+	f, ok := registry[name]
+	if !ok {
+		return nil, fmt.Errorf("remarshal: unknown format %q", name)
+	}
+	return f, nil
+}
+
+func init() {
+	RegisterFormat(tomlFormat{})
+	RegisterFormat(yamlFormat{})
+	RegisterFormat(jsonFormat{})
+	RegisterFormat(jsoncFormat{})
+	RegisterFormat(hclFormat{})
+	RegisterFormat(xmlFormat{sortKeys: true})
+	RegisterFormat(csvFormat{name: "CSV", comma: ','})
+	RegisterFormat(csvFormat{name: "TSV", comma: '\t'})
+}
@@ -0,0 +1,62 @@
+package remarshal
+
+import (
+	"bytes"
+	"encoding/json"
+
+	"github.com/BurntSushi/toml"
+	"gopkg.in/yaml.v2"
+)
+
+// tomlFormat is the built-in Format for TOML.
+type tomlFormat struct{}
+
+func (tomlFormat) Name() string { return "TOML" }
+
+func (tomlFormat) Unmarshal(data []byte) (interface{}, error) {
+	var v interface{}
+	_, err := toml.Decode(string(data), &v)
+	return v, err
+}
+
+func (tomlFormat) Marshal(data interface{}) ([]byte, error) {
+	buf := new(bytes.Buffer)
+	if err := toml.NewEncoder(buf).Encode(data); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// yamlFormat is the built-in Format for YAML.
+type yamlFormat struct{}
+
+func (yamlFormat) Name() string { return "YAML" }
+
+func (yamlFormat) Unmarshal(data []byte) (interface{}, error) {
+	var v interface{}
+	err := yaml.Unmarshal(data, &v)
+	return v, err
+}
+
+func (yamlFormat) Marshal(data interface{}) ([]byte, error) {
+	return yaml.Marshal(&data)
+}
+
+// jsonFormat is the built-in Format for JSON.
+type jsonFormat struct{}
+
+func (jsonFormat) Name() string { return "JSON" }
+
+func (jsonFormat) Unmarshal(data []byte) (interface{}, error) {
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	var v interface{}
+	if err := decoder.Decode(&v); err != nil {
+		return nil, err
+	}
+	return v, nil
+}
+
+func (jsonFormat) Marshal(data interface{}) ([]byte, error) {
+	return json.Marshal(&data)
+}
@@ -0,0 +1,95 @@
+package remarshal
+
+import (
+	"bytes"
+	"encoding/csv"
+	"fmt"
+	"sort"
+)
+
+// csvFormat is the built-in Format for CSV and TSV (selected by comma). A
+// document is represented as a slice of maps, one per data row, keyed by
+// the header row's column names.
+type csvFormat struct {
+	name  string
+	comma rune
+}
+
+func (f csvFormat) Name() string { return f.name }
+
+func (f csvFormat) Unmarshal(data []byte) (interface{}, error) {
+	r := csv.NewReader(bytes.NewReader(data))
+	r.Comma = f.comma
+
+	rows, err := r.ReadAll()
+	if err != nil {
+		return nil, err
+	}
+	if len(rows) == 0 {
+		return []interface{}{}, nil
+	}
+
+	header := rows[0]
+	records := make([]interface{}, 0, len(rows)-1)
+	for _, row := range rows[1:] {
+		rec := make(map[string]interface{}, len(header))
+		for i, col := range header {
+			if i < len(row) {
+				rec[col] = row[i]
+			}
+		}
+		records = append(records, rec)
+	}
+	return records, nil
+}
+
+func (f csvFormat) Marshal(data interface{}) ([]byte, error) {
+	records, ok := data.([]interface{})
+	if !ok {
+		return nil, fmt.Errorf("remarshal: %s output requires a list of records", f.name)
+	}
+
+	seen := map[string]bool{}
+	rows := make([]map[string]string, 0, len(records))
+	for _, item := range records {
+		rec, ok := item.(map[string]interface{})
+		if !ok {
+			return nil, fmt.Errorf("remarshal: %s output requires a list of maps", f.name)
+		}
+
+		row := make(map[string]string, len(rec))
+		for k, v := range rec {
+			seen[k] = true
+			row[k] = fmt.Sprint(v)
+		}
+		rows = append(rows, row)
+	}
+
+	header := make([]string, 0, len(seen))
+	for k := range seen {
+		header = append(header, k)
+	}
+	sort.Strings(header)
+
+	buf := new(bytes.Buffer)
+	w := csv.NewWriter(buf)
+	w.Comma = f.comma
+
+	if err := w.Write(header); err != nil {
+		return nil, err
+	}
+	for _, row := range rows {
+		rec := make([]string, len(header))
+		for i, col := range header {
+			rec[i] = row[col]
+		}
+		if err := w.Write(rec); err != nil {
+			return nil, err
+		}
+	}
+	w.Flush()
+	if err := w.Error(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
@@ -0,0 +1,70 @@
+package remarshal
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/hcl"
+)
+
+// hclFormat is the built-in Format for HCL.
+type hclFormat struct{}
+
+func (hclFormat) Name() string { return "HCL" }
+
+func (hclFormat) Unmarshal(data []byte) (interface{}, error) {
+	var v interface{}
+	if err := hcl.Unmarshal(data, &v); err != nil {
+		return nil, err
+	}
+	return v, nil
+}
+
+// Marshal renders data as HCL. github.com/hashicorp/hcl only implements a
+// decoder, so this covers the common "flat config" case (nested blocks and
+// key = value assignments) rather than the full HCL syntax: no
+// interpolation, heredocs or comments are produced.
+func (hclFormat) Marshal(data interface{}) ([]byte, error) {
+	m, ok := data.(map[string]interface{})
+	if !ok {
+		return nil, errors.New("remarshal: HCL output requires a top-level map")
+	}
+
+	buf := new(bytes.Buffer)
+	if err := writeHCLBody(buf, m, 0); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+func writeHCLBody(buf *bytes.Buffer, m map[string]interface{}, depth int) error {
+	indent := strings.Repeat("  ", depth)
+
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	for _, k := range keys {
+		switch v := m[k].(type) {
+		case map[string]interface{}:
+			fmt.Fprintf(buf, "%s%s {\n", indent, k)
+			if err := writeHCLBody(buf, v, depth+1); err != nil {
+				return err
+			}
+			fmt.Fprintf(buf, "%s}\n", indent)
+		default:
+			b, err := json.Marshal(v)
+			if err != nil {
+				return err
+			}
+			fmt.Fprintf(buf, "%s%s = %s\n", indent, k, b)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,127 @@
+package remarshal
+
+import (
+	"bytes"
+	"encoding/json"
+)
+
+// jsoncFormat is the built-in Format for JSONC/JSON5, i.e. JSON with
+// "//" and "/* */" comments and trailing commas allowed. Input is stripped
+// down to plain JSON and decoded with encoding/json; output is always plain
+// JSON, the same approach chezmoi's formatJSONC takes.
+type jsoncFormat struct{}
+
+func (jsoncFormat) Name() string { return "JSONC" }
+
+func (jsoncFormat) Unmarshal(data []byte) (interface{}, error) {
+	decoder := json.NewDecoder(bytes.NewReader(stripJSONComments(data)))
+	decoder.UseNumber()
+	var v interface{}
+	if err := decoder.Decode(&v); err != nil {
+		return nil, err
+	}
+	return v, nil
+}
+
+func (jsoncFormat) Marshal(data interface{}) ([]byte, error) {
+	return json.Marshal(&data)
+}
+
+// stripJSONComments removes "//" line comments and "/* */" block comments
+// from data, leaving string contents untouched, then strips trailing commas
+// so the result can be decoded with encoding/json.
+func stripJSONComments(data []byte) []byte {
+	var out bytes.Buffer
+	inString := false
+	inLineComment := false
+	inBlockComment := false
+	escaped := false
+
+	for i := 0; i < len(data); i++ {
+		c := data[i]
+		var next byte
+		if i+1 < len(data) {
+			next = data[i+1]
+		}
+
+		switch {
+		case inLineComment:
+			if c == '\n' {
+				inLineComment = false
+				out.WriteByte(c)
+			}
+		case inBlockComment:
+			if c == '*' && next == '/' {
+				inBlockComment = false
+				i++
+			}
+		case inString:
+			out.WriteByte(c)
+			if escaped {
+				escaped = false
+			} else if c == '\\' {
+				escaped = true
+			} else if c == '"' {
+				inString = false
+			}
+		case c == '"':
+			inString = true
+			out.WriteByte(c)
+		case c == '/' && next == '/':
+			inLineComment = true
+			i++
+		case c == '/' && next == '*':
+			inBlockComment = true
+			i++
+		default:
+			out.WriteByte(c)
+		}
+	}
+
+	return stripTrailingCommas(out.Bytes())
+}
+
+// stripTrailingCommas removes commas that precede a closing "]" or "}"
+// (ignoring whitespace in between), which JSON5/JSONC allow but
+// encoding/json does not.
+func stripTrailingCommas(data []byte) []byte {
+	var out bytes.Buffer
+	inString := false
+	escaped := false
+
+	for i := 0; i < len(data); i++ {
+		c := data[i]
+		if inString {
+			out.WriteByte(c)
+			if escaped {
+				escaped = false
+			} else if c == '\\' {
+				escaped = true
+			} else if c == '"' {
+				inString = false
+			}
+			continue
+		}
+		if c == '"' {
+			inString = true
+			out.WriteByte(c)
+			continue
+		}
+		if c == ',' {
+			j := i + 1
+			for j < len(data) && isJSONSpace(data[j]) {
+				j++
+			}
+			if j < len(data) && (data[j] == ']' || data[j] == '}') {
+				continue
+			}
+		}
+		out.WriteByte(c)
+	}
+
+	return out.Bytes()
+}
+
+func isJSONSpace(b byte) bool {
+	return b == ' ' || b == '\t' || b == '\n' || b == '\r'
+}
@@ -0,0 +1,144 @@
+package remarshal
+
+import "testing"
+
+func TestFormatRoundTrip(t *testing.T) {
+	data := map[string]interface{}{"a": "x", "b": "y"}
+
+	for _, name := range []string{"TOML", "YAML", "JSON", "JSONC", "HCL"} {
+		name := name
+		t.Run(name, func(t *testing.T) {
+			f, err := lookupFormat(name)
+			if err != nil {
+				t.Fatalf("lookupFormat(%q): %v", name, err)
+			}
+
+			encoded, err := f.Marshal(data)
+			if err != nil {
+				t.Fatalf("Marshal: %v", err)
+			}
+
+			decoded, err := f.Unmarshal(encoded)
+			if err != nil {
+				t.Fatalf("Unmarshal(%s): %v", encoded, err)
+			}
+			decoded, err = convertMapsToStringMaps(decoded)
+			if err != nil {
+				t.Fatalf("convertMapsToStringMaps: %v", err)
+			}
+
+			m, ok := decoded.(map[string]interface{})
+			if !ok {
+				t.Fatalf("decoded value is %T, want map[string]interface{}", decoded)
+			}
+			if m["a"] != "x" || m["b"] != "y" {
+				t.Fatalf("round trip mismatch: got %#v", m)
+			}
+		})
+	}
+}
+
+func TestFormatXMLRoundTrip(t *testing.T) {
+	f, err := lookupFormat("XML")
+	if err != nil {
+		t.Fatalf("lookupFormat: %v", err)
+	}
+
+	data := map[string]interface{}{"root": map[string]interface{}{"a": "x", "b": "y"}}
+	encoded, err := f.Marshal(data)
+	if err != nil {
+		t.Fatalf("Marshal: %v", err)
+	}
+
+	decoded, err := f.Unmarshal(encoded)
+	if err != nil {
+		t.Fatalf("Unmarshal(%s): %v", encoded, err)
+	}
+
+	root, ok := decoded.(map[string]interface{})["root"].(map[string]interface{})
+	if !ok {
+		t.Fatalf("decoded value is %#v, want nested root map", decoded)
+	}
+	if root["a"] != "x" || root["b"] != "y" {
+		t.Fatalf("round trip mismatch: got %#v", root)
+	}
+}
+
+func TestFormatCSVRoundTrip(t *testing.T) {
+	for _, name := range []string{"CSV", "TSV"} {
+		name := name
+		t.Run(name, func(t *testing.T) {
+			f, err := lookupFormat(name)
+			if err != nil {
+				t.Fatalf("lookupFormat(%q): %v", name, err)
+			}
+
+			records := []interface{}{
+				map[string]interface{}{"a": "1", "b": "2"},
+				map[string]interface{}{"a": "3", "b": "4"},
+			}
+
+			encoded, err := f.Marshal(records)
+			if err != nil {
+				t.Fatalf("Marshal: %v", err)
+			}
+
+			decoded, err := f.Unmarshal(encoded)
+			if err != nil {
+				t.Fatalf("Unmarshal(%s): %v", encoded, err)
+			}
+
+			rows, ok := decoded.([]interface{})
+			if !ok || len(rows) != 2 {
+				t.Fatalf("decoded value is %#v, want 2 records", decoded)
+			}
+		})
+	}
+}
+
+func TestFormatCSVMarshalDeterministicHeader(t *testing.T) {
+	f, err := lookupFormat("CSV")
+	if err != nil {
+		t.Fatalf("lookupFormat: %v", err)
+	}
+
+	records := []interface{}{
+		map[string]interface{}{"z": "1", "a": "2", "m": "3"},
+	}
+
+	var first string
+	for i := 0; i < 10; i++ {
+		encoded, err := f.Marshal(records)
+		if err != nil {
+			t.Fatalf("Marshal: %v", err)
+		}
+		if i == 0 {
+			first = string(encoded)
+			continue
+		}
+		if string(encoded) != first {
+			t.Fatalf("CSV header order is not deterministic: got %q, want %q", encoded, first)
+		}
+	}
+}
+
+func TestRegisterFormatOverride(t *testing.T) {
+	defer RegisterFormat(jsonFormat{})
+
+	RegisterFormat(stubFormat{})
+	f, err := lookupFormat("JSON")
+	if err != nil {
+		t.Fatalf("lookupFormat: %v", err)
+	}
+	if _, ok := f.(stubFormat); !ok {
+		t.Fatalf("RegisterFormat did not override the built-in JSON format")
+	}
+}
+
+type stubFormat struct{}
+
+func (stubFormat) Name() string { return "JSON" }
+
+func (stubFormat) Unmarshal(data []byte) (interface{}, error) { return nil, nil }
+
+func (stubFormat) Marshal(data interface{}) ([]byte, error) { return nil, nil }
@@ -0,0 +1,137 @@
+package remarshal
+
+import (
+	"bytes"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+)
+
+// xmlFormat is the built-in Format for XML. A document is represented as
+// nested maps and slices the same way the other formats are: an element
+// with children becomes a map keyed by child name, an element with no
+// children becomes its trimmed text content, and repeated sibling elements
+// become a slice. Attributes are not preserved.
+//
+// sortKeys controls whether sibling elements are emitted in sorted order;
+// Go map iteration is otherwise unordered. Convert sets it from
+// Options.SortMapKeys.
+type xmlFormat struct {
+	sortKeys bool
+}
+
+func (xmlFormat) Name() string { return "XML" }
+
+func (xmlFormat) Unmarshal(data []byte) (interface{}, error) {
+	dec := xml.NewDecoder(bytes.NewReader(data))
+
+	for {
+		tok, err := dec.Token()
+		if err == io.EOF {
+			return nil, errors.New("remarshal: empty XML document")
+		}
+		if err != nil {
+			return nil, err
+		}
+		if start, ok := tok.(xml.StartElement); ok {
+			v, err := decodeXMLElement(dec, start)
+			if err != nil {
+				return nil, err
+			}
+			return map[string]interface{}{start.Name.Local: v}, nil
+		}
+	}
+}
+
+func decodeXMLElement(dec *xml.Decoder, start xml.StartElement) (interface{}, error) {
+	children := map[string]interface{}{}
+	var text bytes.Buffer
+
+	for {
+		tok, err := dec.Token()
+		if err != nil {
+			return nil, err
+		}
+		switch t := tok.(type) {
+		case xml.StartElement:
+			v, err := decodeXMLElement(dec, t)
+			if err != nil {
+				return nil, err
+			}
+			addXMLChild(children, t.Name.Local, v)
+		case xml.CharData:
+			text.Write(t)
+		case xml.EndElement:
+			if len(children) == 0 {
+				return strings.TrimSpace(text.String()), nil
+			}
+			return children, nil
+		}
+	}
+}
+
+func addXMLChild(children map[string]interface{}, name string, value interface{}) {
+	existing, ok := children[name]
+	if !ok {
+		children[name] = value
+		return
+	}
+	if list, ok := existing.([]interface{}); ok {
+		children[name] = append(list, value)
+		return
+	}
+	children[name] = []interface{}{existing, value}
+}
+
+func (f xmlFormat) Marshal(data interface{}) ([]byte, error) {
+	m, ok := data.(map[string]interface{})
+	if !ok || len(m) != 1 {
+		return nil, errors.New("remarshal: XML output requires a single root element (a map with exactly one top-level key)")
+	}
+
+	buf := new(bytes.Buffer)
+	for name, v := range m {
+		if err := f.encodeXMLElement(buf, name, v); err != nil {
+			return nil, err
+		}
+	}
+	return buf.Bytes(), nil
+}
+
+func (f xmlFormat) encodeXMLElement(buf *bytes.Buffer, name string, value interface{}) error {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		fmt.Fprintf(buf, "<%s>", name)
+		for _, k := range xmlChildKeys(v, f.sortKeys) {
+			if err := f.encodeXMLElement(buf, k, v[k]); err != nil {
+				return err
+			}
+		}
+		fmt.Fprintf(buf, "</%s>", name)
+	case []interface{}:
+		for _, item := range v {
+			if err := f.encodeXMLElement(buf, name, item); err != nil {
+				return err
+			}
+		}
+	default:
+		fmt.Fprintf(buf, "<%s>", name)
+		xml.EscapeText(buf, []byte(fmt.Sprint(v)))
+		fmt.Fprintf(buf, "</%s>", name)
+	}
+	return nil
+}
+
+func xmlChildKeys(m map[string]interface{}, sortKeys bool) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	if sortKeys {
+		sort.Strings(keys)
+	}
+	return keys
+}
@@ -0,0 +1,205 @@
+package remarshal
+
+import (
+	"bytes"
+	"errors"
+)
+
+// FrontMatterFormat selects the delimiter style ConvertFrontMatter uses for
+// its output.
+type FrontMatterFormat int
+
+const (
+	// FrontMatterYAML wraps the metadata block in "---" delimiters.
+	FrontMatterYAML FrontMatterFormat = iota
+	// FrontMatterTOML wraps the metadata block in "+++" delimiters.
+	FrontMatterTOML
+	// FrontMatterJSON writes the metadata block as a bare JSON object, with
+	// no delimiter lines of its own (the braces are the block), matching
+	// Hugo's JSON front matter style.
+	FrontMatterJSON
+)
+
+// formatName returns the registered Format name ConvertFrontMatter uses to
+// encode this front-matter style's metadata block.
+func (f FrontMatterFormat) formatName() string {
+	switch f {
+	case FrontMatterTOML:
+		return "TOML"
+	case FrontMatterJSON:
+		return "JSON"
+	default:
+		return "YAML"
+	}
+}
+
+var bomBytes = []byte{0xEF, 0xBB, 0xBF}
+
+// frontMatterBlock is a document's leading front-matter block: which format
+// it was written in, its metadata bytes with delimiters stripped, and the
+// rest of the document verbatim.
+type frontMatterBlock struct {
+	format string
+	data   []byte
+	body   []byte
+}
+
+// splitFrontMatter detects and extracts the leading front-matter block from
+// a Markdown/text document: "---"-delimited YAML, "+++"-delimited TOML, or
+// a bare "{ ... }" JSON object — the three styles Hugo's metadecoders
+// supports. It reports false if doc has no recognizable front matter.
+func splitFrontMatter(doc []byte) (frontMatterBlock, bool) {
+	switch {
+	case bytes.HasPrefix(doc, []byte("---")):
+		return splitDelimitedFrontMatter(doc, "---", "YAML")
+	case bytes.HasPrefix(doc, []byte("+++")):
+		return splitDelimitedFrontMatter(doc, "+++", "TOML")
+	case bytes.HasPrefix(doc, []byte("{")):
+		return splitJSONFrontMatter(doc)
+	default:
+		return frontMatterBlock{}, false
+	}
+}
+
+func splitDelimitedFrontMatter(doc []byte, delim, format string) (frontMatterBlock, bool) {
+	line, rest, ok := cutLine(doc)
+	if !ok || trimCR(line) != delim {
+		return frontMatterBlock{}, false
+	}
+
+	var metadata bytes.Buffer
+	for {
+		line, next, ok := cutLine(rest)
+		if !ok {
+			return frontMatterBlock{}, false
+		}
+		if trimCR(line) == delim {
+			return frontMatterBlock{format: format, data: metadata.Bytes(), body: next}, true
+		}
+		metadata.Write(line)
+		metadata.WriteByte('\n')
+		rest = next
+	}
+}
+
+// splitJSONFrontMatter extracts a leading bare JSON object by brace
+// counting, respecting string literals and escapes, and treats everything
+// after its matching closing brace (minus one separating newline) as body.
+func splitJSONFrontMatter(doc []byte) (frontMatterBlock, bool) {
+	depth := 0
+	inString := false
+	escaped := false
+	end := -1
+
+	for i, c := range doc {
+		switch {
+		case inString:
+			switch {
+			case escaped:
+				escaped = false
+			case c == '\\':
+				escaped = true
+			case c == '"':
+				inString = false
+			}
+		case c == '"':
+			inString = true
+		case c == '{':
+			depth++
+		case c == '}':
+			depth--
+			if depth == 0 {
+				end = i
+			}
+		}
+		if end != -1 {
+			break
+		}
+	}
+	if end == -1 {
+		return frontMatterBlock{}, false
+	}
+
+	metadata := doc[:end+1]
+	body := doc[end+1:]
+	switch {
+	case bytes.HasPrefix(body, []byte("\r\n")):
+		body = body[2:]
+	case bytes.HasPrefix(body, []byte("\n")):
+		body = body[1:]
+	}
+
+	return frontMatterBlock{format: "JSON", data: metadata, body: body}, true
+}
+
+// cutLine splits s at the first newline, returning the line (without the
+// newline) and the remainder. If s has no newline, the whole of s is
+// returned as a final line with a nil remainder, so a document whose last
+// line is its closing delimiter (no trailing newline) is still detected.
+// ok is false only once s itself is empty, meaning there's no more input
+// and no closing delimiter was found.
+func cutLine(s []byte) (line []byte, rest []byte, ok bool) {
+	if len(s) == 0 {
+		return nil, nil, false
+	}
+	i := bytes.IndexByte(s, '\n')
+	if i == -1 {
+		return s, nil, true
+	}
+	return s[:i], s[i+1:], true
+}
+
+func trimCR(line []byte) string {
+	return string(bytes.TrimSuffix(line, []byte("\r")))
+}
+
+// ConvertFrontMatter detects the leading front-matter block of a
+// Markdown/text document, converts only that block to outputFormat, and
+// re-emits the document with the new delimiters and the original body
+// preserved byte-for-byte. A leading UTF-8 BOM, and the document's trailing
+// newlines (they're part of the preserved body), are carried through
+// unchanged.
+func ConvertFrontMatter(doc []byte, outputFormat FrontMatterFormat) ([]byte, error) {
+	bom := []byte{}
+	if bytes.HasPrefix(doc, bomBytes) {
+		bom = bomBytes
+		doc = doc[len(bomBytes):]
+	}
+
+	block, ok := splitFrontMatter(doc)
+	if !ok {
+		return nil, errors.New("remarshal: no recognizable front matter found")
+	}
+
+	metadata := block.data
+	if block.format != outputFormat.formatName() {
+		converted, err := Convert(block.data, block.format, outputFormat.formatName())
+		if err != nil {
+			return nil, err
+		}
+		metadata = []byte(converted)
+	}
+	if len(metadata) > 0 && metadata[len(metadata)-1] != '\n' {
+		metadata = append(metadata, '\n')
+	}
+
+	var out bytes.Buffer
+	out.Write(bom)
+
+	switch outputFormat {
+	case FrontMatterTOML:
+		out.WriteString("+++\n")
+		out.Write(metadata)
+		out.WriteString("+++\n")
+	case FrontMatterJSON:
+		out.Write(metadata)
+	default:
+		out.WriteString("---\n")
+		out.Write(metadata)
+		out.WriteString("---\n")
+	}
+
+	out.Write(block.body)
+
+	return out.Bytes(), nil
+}
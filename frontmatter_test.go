@@ -0,0 +1,132 @@
+package remarshal
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestConvertFrontMatterYAMLToTOML(t *testing.T) {
+	doc := []byte("---\ntitle: Hello\n---\n# Body\n\nSome text.\n")
+
+	out, err := ConvertFrontMatter(doc, FrontMatterTOML)
+	if err != nil {
+		t.Fatalf("ConvertFrontMatter: %v", err)
+	}
+
+	s := string(out)
+	if !strings.HasPrefix(s, "+++\n") {
+		t.Fatalf("expected +++ delimiters, got: %s", s)
+	}
+	if !strings.Contains(s, `title = "Hello"`) {
+		t.Fatalf("expected converted TOML metadata, got: %s", s)
+	}
+	if !strings.HasSuffix(s, "# Body\n\nSome text.\n") {
+		t.Fatalf("expected body preserved verbatim, got: %s", s)
+	}
+}
+
+func TestConvertFrontMatterTOMLToYAML(t *testing.T) {
+	doc := []byte("+++\ntitle = \"Hello\"\n+++\nBody text\n")
+
+	out, err := ConvertFrontMatter(doc, FrontMatterYAML)
+	if err != nil {
+		t.Fatalf("ConvertFrontMatter: %v", err)
+	}
+
+	s := string(out)
+	if !strings.HasPrefix(s, "---\n") {
+		t.Fatalf("expected --- delimiters, got: %s", s)
+	}
+	if !strings.Contains(s, "title: Hello") {
+		t.Fatalf("expected converted YAML metadata, got: %s", s)
+	}
+	if !strings.HasSuffix(s, "Body text\n") {
+		t.Fatalf("expected body preserved verbatim, got: %s", s)
+	}
+}
+
+func TestConvertFrontMatterJSONDetection(t *testing.T) {
+	doc := []byte("{\n\"title\": \"Hello\"\n}\nBody text\n")
+
+	out, err := ConvertFrontMatter(doc, FrontMatterYAML)
+	if err != nil {
+		t.Fatalf("ConvertFrontMatter: %v", err)
+	}
+
+	s := string(out)
+	if !strings.HasPrefix(s, "---\n") {
+		t.Fatalf("expected --- delimiters, got: %s", s)
+	}
+	if !strings.HasSuffix(s, "Body text\n") {
+		t.Fatalf("expected body preserved verbatim, got: %s", s)
+	}
+}
+
+func TestConvertFrontMatterToJSONHasNoDelimiters(t *testing.T) {
+	doc := []byte("---\ntitle: Hello\n---\nBody\n")
+
+	out, err := ConvertFrontMatter(doc, FrontMatterJSON)
+	if err != nil {
+		t.Fatalf("ConvertFrontMatter: %v", err)
+	}
+
+	s := string(out)
+	if strings.Contains(s, "---") || strings.Contains(s, "+++") {
+		t.Fatalf("expected no delimiter lines around JSON front matter, got: %s", s)
+	}
+	if !strings.HasPrefix(s, "{") {
+		t.Fatalf("expected JSON front matter to start with '{', got: %s", s)
+	}
+}
+
+func TestConvertFrontMatterPreservesBOM(t *testing.T) {
+	doc := append(append([]byte{}, bomBytes...), []byte("---\ntitle: Hello\n---\nBody\n")...)
+
+	out, err := ConvertFrontMatter(doc, FrontMatterTOML)
+	if err != nil {
+		t.Fatalf("ConvertFrontMatter: %v", err)
+	}
+
+	if !bytes.HasPrefix(out, bomBytes) {
+		t.Fatalf("expected leading BOM preserved, got: %x", out[:4])
+	}
+}
+
+func TestConvertFrontMatterPreservesTrailingNewlines(t *testing.T) {
+	doc := []byte("---\ntitle: Hello\n---\nBody\n\n\n")
+
+	out, err := ConvertFrontMatter(doc, FrontMatterTOML)
+	if err != nil {
+		t.Fatalf("ConvertFrontMatter: %v", err)
+	}
+
+	if !strings.HasSuffix(string(out), "Body\n\n\n") {
+		t.Fatalf("expected trailing newlines preserved, got: %q", out)
+	}
+}
+
+func TestConvertFrontMatterOnlyNoTrailingNewline(t *testing.T) {
+	doc := []byte("---\ntitle: Hello\n---")
+
+	out, err := ConvertFrontMatter(doc, FrontMatterTOML)
+	if err != nil {
+		t.Fatalf("ConvertFrontMatter: %v", err)
+	}
+
+	s := string(out)
+	if !strings.HasPrefix(s, "+++\n") || !strings.Contains(s, `title = "Hello"`) {
+		t.Fatalf("expected converted TOML metadata, got: %s", s)
+	}
+	if !strings.HasSuffix(s, "+++\n") {
+		t.Fatalf("expected no leftover body after front matter, got: %q", s)
+	}
+}
+
+func TestConvertFrontMatterNoFrontMatter(t *testing.T) {
+	doc := []byte("# Just a heading\n\nNo front matter here.\n")
+
+	if _, err := ConvertFrontMatter(doc, FrontMatterTOML); err == nil {
+		t.Fatalf("expected an error when no front matter is present")
+	}
+}
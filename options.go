@@ -0,0 +1,239 @@
+package remarshal
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"strings"
+
+	"gopkg.in/yaml.v2"
+)
+
+// NumberMode selects how numbers decoded from a JSON-like input format
+// (JSON, JSONC) are represented in the decoded data before it's re-encoded
+// in the output format.
+type NumberMode int
+
+const (
+	// NumbersAsInt64Float64 converts json.Number values to int64, falling
+	// back to float64 when the number doesn't fit either. This is
+	// Convert's original, and still default, behavior.
+	NumbersAsInt64Float64 NumberMode = iota
+
+	// NumbersAsJSONNumber leaves numbers as json.Number, so a JSON/JSONC
+	// input marshaled back to JSON/JSONC keeps the exact decimal text
+	// (e.g. a number too large for int64) instead of losing precision to
+	// NumbersAsInt64Float64's conversion. It doesn't help other output
+	// formats: both toml.Encoder and yaml.v2 convert an out-of-int64-range
+	// json.Number to a lossy float64 regardless of mode, since neither
+	// gives json.Number any special handling for values that large. Use
+	// NumbersAsString to preserve big integers across formats.
+	NumbersAsJSONNumber
+
+	// NumbersAsString converts numbers to their decimal string
+	// representation. Unlike NumbersAsJSONNumber, this preserves exact
+	// big-integer precision into every output format, since the value is
+	// just a string by the time the target encoder sees it.
+	NumbersAsString
+)
+
+// Options configures ConvertWithOptions. The zero value is not generally
+// useful as-is; start from DefaultOptions and override what you need.
+type Options struct {
+	// NumberMode selects how numbers decoded from JSON/JSONC input are
+	// represented.
+	NumberMode NumberMode
+
+	// IndentJSON controls whether JSON/JSONC output is pretty-printed.
+	IndentJSON bool
+	// IndentString is the indent used per level when IndentJSON is true.
+	// Defaults to two spaces if left empty.
+	IndentString string
+
+	// SortMapKeys sorts map keys before encoding, for output formats that
+	// don't already guarantee a deterministic key order. Currently affects
+	// XML output; JSON, YAML and HCL already encode map keys in sorted
+	// order regardless of this option.
+	SortMapKeys bool
+
+	// YAMLFlowStyle emits YAML output in flow (JSON-like, single-line)
+	// style instead of block style.
+	YAMLFlowStyle bool
+
+	// TOMLArraysMultiline puts each TOML array element on its own line
+	// instead of emitting the array on a single line.
+	TOMLArraysMultiline bool
+}
+
+// DefaultOptions returns the Options Convert has always used: int64/float64
+// numbers, two-space-indented JSON, sorted map keys, and block-style
+// YAML/single-line TOML arrays.
+func DefaultOptions() Options {
+	return Options{
+		NumberMode:   NumbersAsInt64Float64,
+		IndentJSON:   true,
+		IndentString: "  ",
+		SortMapKeys:  true,
+	}
+}
+
+// Convert converts input from inputF to outputF using DefaultOptions.
+// inputF and outputF can be the name of any registered Format: built in are
+// TOML, JSON, JSONC, YAML, HCL, XML, CSV and TSV.
+func Convert(input []byte, inputF, outputF string) (string, error) {
+	return ConvertWithOptions(input, inputF, outputF, DefaultOptions())
+}
+
+// ConvertWithOptions converts input from inputF to outputF the same way
+// Convert does, but lets callers override number handling, indentation and
+// the per-format encoding knobs in opts.
+func ConvertWithOptions(input []byte, inputF, outputF string, opts Options) (string, error) {
+	if inputF == outputF {
+		return "", errors.New("Input and output formats cannot be the same")
+	}
+
+	if _, err := lookupFormat(inputF); err != nil {
+		return "", err
+	}
+	if _, err := lookupFormat(outputF); err != nil {
+		return "", err
+	}
+
+	data, err := unmarshal(input, inputF, opts)
+	if err != nil {
+		return "", err
+	}
+
+	output, err := marshal(data, outputF, opts)
+	if err != nil {
+		return "", err
+	}
+
+	return string(output), nil
+}
+
+// marshalYAMLFlow renders data as single-line, JSON-like YAML flow style.
+// gopkg.in/yaml.v2 only controls flow style through struct tags, which
+// aren't available for generic map[string]interface{}/[]interface{} data,
+// so this walks the data itself rather than going through yaml.Marshal for
+// collections; scalars still delegate to yaml.Marshal for correct quoting.
+func marshalYAMLFlow(data interface{}) ([]byte, error) {
+	buf := new(bytes.Buffer)
+	if err := writeYAMLFlow(buf, data); err != nil {
+		return nil, err
+	}
+	buf.WriteByte('\n')
+	return buf.Bytes(), nil
+}
+
+func writeYAMLFlow(buf *bytes.Buffer, data interface{}) error {
+	switch v := data.(type) {
+	case map[string]interface{}:
+		buf.WriteByte('{')
+		for i, k := range xmlChildKeys(v, true) {
+			if i > 0 {
+				buf.WriteString(", ")
+			}
+			fmt.Fprintf(buf, "%s: ", k)
+			if err := writeYAMLFlow(buf, v[k]); err != nil {
+				return err
+			}
+		}
+		buf.WriteByte('}')
+	case []interface{}:
+		buf.WriteByte('[')
+		for i, item := range v {
+			if i > 0 {
+				buf.WriteString(", ")
+			}
+			if err := writeYAMLFlow(buf, item); err != nil {
+				return err
+			}
+		}
+		buf.WriteByte(']')
+	default:
+		b, err := yaml.Marshal(v)
+		if err != nil {
+			return err
+		}
+		buf.WriteString(strings.TrimSpace(string(b)))
+	}
+	return nil
+}
+
+// reformatTOMLArraysMultiline rewrites single-line TOML array values
+// ("key = [a, b, c]") onto multiple lines, one element per line.
+// github.com/BurntSushi/toml's Encoder doesn't expose that as an option, so
+// this is a textual post-process over its output instead.
+func reformatTOMLArraysMultiline(data []byte) []byte {
+	lines := strings.Split(string(data), "\n")
+	out := make([]string, 0, len(lines))
+
+	for _, line := range lines {
+		eq := strings.Index(line, "=")
+		if eq == -1 {
+			out = append(out, line)
+			continue
+		}
+
+		value := strings.TrimSpace(line[eq+1:])
+		if !strings.HasPrefix(value, "[") || !strings.HasSuffix(value, "]") {
+			out = append(out, line)
+			continue
+		}
+
+		inner := strings.TrimSpace(value[1 : len(value)-1])
+		if inner == "" {
+			out = append(out, line)
+			continue
+		}
+
+		key := strings.TrimSpace(line[:eq])
+		out = append(out, key+" = [")
+		for _, e := range splitTOMLArrayElements(inner) {
+			out = append(out, "  "+strings.TrimSpace(e)+",")
+		}
+		out = append(out, "]")
+	}
+
+	return []byte(strings.Join(out, "\n"))
+}
+
+// splitTOMLArrayElements splits the contents of a TOML array on top-level
+// commas, respecting quoted strings and nested brackets.
+func splitTOMLArrayElements(s string) []string {
+	var elems []string
+	var cur strings.Builder
+	depth := 0
+	inString := false
+
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		switch {
+		case inString:
+			cur.WriteByte(c)
+			if c == '"' && (i == 0 || s[i-1] != '\\') {
+				inString = false
+			}
+		case c == '"':
+			inString = true
+			cur.WriteByte(c)
+		case c == '[' || c == '{':
+			depth++
+			cur.WriteByte(c)
+		case c == ']' || c == '}':
+			depth--
+			cur.WriteByte(c)
+		case c == ',' && depth == 0:
+			elems = append(elems, cur.String())
+			cur.Reset()
+		default:
+			cur.WriteByte(c)
+		}
+	}
+	if strings.TrimSpace(cur.String()) != "" {
+		elems = append(elems, cur.String())
+	}
+
+	return elems
+}
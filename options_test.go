@@ -0,0 +1,150 @@
+package remarshal
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestConvertWithOptionsNumberModes(t *testing.T) {
+	input := []byte(`{"num": 42}`)
+
+	t.Run("Int64Float64", func(t *testing.T) {
+		opts := DefaultOptions()
+		opts.NumberMode = NumbersAsInt64Float64
+		out, err := ConvertWithOptions(input, "JSON", "YAML", opts)
+		if err != nil {
+			t.Fatalf("ConvertWithOptions: %v", err)
+		}
+		if !strings.Contains(out, "num: 42") {
+			t.Fatalf("unexpected output: %s", out)
+		}
+	})
+
+	t.Run("JSONNumber", func(t *testing.T) {
+		// A number outside int64 range loses precision once converted to
+		// int64/float64 (the default); NumbersAsJSONNumber should carry its
+		// exact decimal text straight through a JSON round trip instead.
+		bigNumber := []byte(`{"num": 12345678901234567890}`)
+
+		opts := DefaultOptions()
+		opts.NumberMode = NumbersAsJSONNumber
+		out, err := ConvertWithOptions(bigNumber, "JSON", "JSONC", opts)
+		if err != nil {
+			t.Fatalf("ConvertWithOptions: %v", err)
+		}
+		if !strings.Contains(out, "12345678901234567890") {
+			t.Fatalf("expected exact big-integer precision preserved, got: %s", out)
+		}
+
+		opts.NumberMode = NumbersAsInt64Float64
+		out, err = ConvertWithOptions(bigNumber, "JSON", "JSONC", opts)
+		if err != nil {
+			t.Fatalf("ConvertWithOptions: %v", err)
+		}
+		if strings.Contains(out, "12345678901234567890") {
+			t.Fatalf("expected default NumberMode to lose big-integer precision, got: %s", out)
+		}
+	})
+
+	t.Run("String", func(t *testing.T) {
+		opts := DefaultOptions()
+		opts.NumberMode = NumbersAsString
+		out, err := ConvertWithOptions(input, "JSON", "YAML", opts)
+		if err != nil {
+			t.Fatalf("ConvertWithOptions: %v", err)
+		}
+		if !strings.Contains(out, `num: "42"`) {
+			t.Fatalf("expected quoted string number, got: %s", out)
+		}
+	})
+}
+
+func TestConvertWithOptionsNumberModesBigIntegerToOtherFormats(t *testing.T) {
+	// NumbersAsJSONNumber only preserves exact precision within JSON/JSONC;
+	// toml.Encoder and yaml.v2 both fall back to a lossy float64 for a
+	// json.Number outside int64 range, regardless of NumberMode. Only
+	// NumbersAsString survives the trip into TOML/YAML intact.
+	bigNumber := []byte(`{"num": 12345678901234567890}`)
+
+	for _, outputFormat := range []string{"TOML", "YAML"} {
+		outputFormat := outputFormat
+		t.Run(outputFormat, func(t *testing.T) {
+			opts := DefaultOptions()
+			opts.NumberMode = NumbersAsJSONNumber
+			out, err := ConvertWithOptions(bigNumber, "JSON", outputFormat, opts)
+			if err != nil {
+				t.Fatalf("ConvertWithOptions: %v", err)
+			}
+			if strings.Contains(out, "12345678901234567890") {
+				t.Fatalf("expected NumbersAsJSONNumber to lose precision converting to %s, got: %s", outputFormat, out)
+			}
+
+			opts.NumberMode = NumbersAsString
+			out, err = ConvertWithOptions(bigNumber, "JSON", outputFormat, opts)
+			if err != nil {
+				t.Fatalf("ConvertWithOptions: %v", err)
+			}
+			if !strings.Contains(out, "12345678901234567890") {
+				t.Fatalf("expected NumbersAsString to preserve precision converting to %s, got: %s", outputFormat, out)
+			}
+		})
+	}
+}
+
+func TestConvertWithOptionsIndent(t *testing.T) {
+	input := []byte(`{"a": {"b": 1}}`)
+
+	opts := DefaultOptions()
+	opts.IndentString = "    "
+	out, err := ConvertWithOptions(input, "JSON", "JSON", opts)
+	if err == nil {
+		t.Fatalf("expected same-format conversion to error, got output: %s", out)
+	}
+
+	out, err = ConvertWithOptions(input, "JSON", "JSONC", opts)
+	if err != nil {
+		t.Fatalf("ConvertWithOptions: %v", err)
+	}
+	if !strings.Contains(out, "    \"b\"") {
+		t.Fatalf("expected 4-space indent, got: %s", out)
+	}
+}
+
+func TestConvertWithOptionsYAMLFlowStyle(t *testing.T) {
+	input := []byte(`{"a": 1, "b": [1, 2]}`)
+
+	opts := DefaultOptions()
+	opts.YAMLFlowStyle = true
+	out, err := ConvertWithOptions(input, "JSON", "YAML", opts)
+	if err != nil {
+		t.Fatalf("ConvertWithOptions: %v", err)
+	}
+	if !strings.HasPrefix(strings.TrimSpace(out), "{") {
+		t.Fatalf("expected flow-style YAML, got: %s", out)
+	}
+}
+
+func TestConvertWithOptionsTOMLArraysMultiline(t *testing.T) {
+	input := []byte(`{"a": [1, 2, 3]}`)
+
+	opts := DefaultOptions()
+	opts.TOMLArraysMultiline = true
+	out, err := ConvertWithOptions(input, "JSON", "TOML", opts)
+	if err != nil {
+		t.Fatalf("ConvertWithOptions: %v", err)
+	}
+	if !strings.Contains(out, "a = [\n") {
+		t.Fatalf("expected multiline TOML array, got: %s", out)
+	}
+}
+
+func TestConvertUsesDefaultOptions(t *testing.T) {
+	input := []byte(`{"num": 9007199254740993}`)
+	out, err := Convert(input, "JSON", "YAML")
+	if err != nil {
+		t.Fatalf("Convert: %v", err)
+	}
+	if strings.Contains(out, "e+") {
+		t.Fatalf("expected large integer preserved without scientific notation, got: %s", out)
+	}
+}
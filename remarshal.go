@@ -7,19 +7,6 @@ package remarshal
 import (
 	"bytes"
 	"encoding/json"
-	"errors"
-	"github.com/BurntSushi/toml"
-	"gopkg.in/yaml.v2"
-)
-
-type format int
-
-const (
-	fTOML format = iota
-	fYAML
-	fJSON
-	fPlaceholder
-	fUnknown
 )
 
 // convertMapsToStringMaps recursively converts values of type
@@ -91,108 +78,123 @@ func convertNumbersToInt64(item interface{}) (res interface{}, err error) {
 	}
 }
 
-// unmarshal decodes serialized data in the format inputFormat into a structure
-// of nested maps and slices.
-func unmarshal(input []byte, inputFormat format) (data interface{},
-	err error) {
-	switch inputFormat {
-	case fTOML:
-		_, err = toml.Decode(string(input), &data)
-	case fYAML:
-		err = yaml.Unmarshal(input, &data)
-		if err == nil {
-			data, err = convertMapsToStringMaps(data)
+// convertNumbersToString recursively walks the structures contained in item
+// converting values of the type json.Number to their decimal string
+// representation.
+func convertNumbersToString(item interface{}) (res interface{}, err error) {
+	switch item.(type) {
+	case map[string]interface{}:
+		res := make(map[string]interface{})
+		for k, v := range item.(map[string]interface{}) {
+			res[k], err = convertNumbersToString(v)
+			if err != nil {
+				return nil, err
+			}
 		}
-	case fJSON:
-		decoder := json.NewDecoder(bytes.NewReader(input))
-		decoder.UseNumber()
-		err = decoder.Decode(&data)
-		if err == nil {
-			data, err = convertNumbersToInt64(data)
+		return res, nil
+	case []interface{}:
+		res := make([]interface{}, len(item.([]interface{})))
+		for i, v := range item.([]interface{}) {
+			res[i], err = convertNumbersToString(v)
+			if err != nil {
+				return nil, err
+			}
 		}
+		return res, nil
+	case json.Number:
+		return item.(json.Number).String(), nil
+	default:
+		return item, nil
 	}
-	if err != nil {
-		return nil, err
-	}
-	return
 }
 
-// marshal encodes data stored in nested maps and slices in the format
-// outputFormat.
-func marshal(data interface{}, outputFormat format,
-	indentJSON bool) (result []byte, err error) {
-	switch outputFormat {
-	case fTOML:
-		buf := new(bytes.Buffer)
-		err = toml.NewEncoder(buf).Encode(data)
-		result = buf.Bytes()
-	case fYAML:
-		result, err = yaml.Marshal(&data)
-	case fJSON:
-		result, err = json.Marshal(&data)
-		if err == nil && indentJSON {
-			buf := new(bytes.Buffer)
-			err = json.Indent(buf, result, "", "  ")
-			result = buf.Bytes()
-		}
-	}
-	if err != nil {
-		return nil, err
+// applyNumberMode converts the json.Number values left behind by a
+// JSON-like format's Unmarshal according to mode.
+func applyNumberMode(item interface{}, mode NumberMode) (interface{}, error) {
+	switch mode {
+	case NumbersAsJSONNumber:
+		return item, nil
+	case NumbersAsString:
+		return convertNumbersToString(item)
+	default:
+		return convertNumbersToInt64(item)
 	}
-	return
 }
 
-//inputF and outputF can be any of the following: TOML, JSON, YAML
-func Convert(input []byte, inputF, outputF string) (string, error) {
+// usesJSONNumber reports whether formatName decodes numbers as json.Number,
+// and so needs its output run through applyNumberMode.
+func usesJSONNumber(formatName string) bool {
+	return formatName == "JSON" || formatName == "JSONC"
+}
 
-	if inputF == outputF {
-		return "", errors.New("Input and output formats cannot be the same")
+// unmarshal decodes serialized data in the format named inputFormat into a
+// structure of nested maps and slices.
+func unmarshal(input []byte, inputFormat string, opts Options) (data interface{}, err error) {
+	f, err := lookupFormat(inputFormat)
+	if err != nil {
+		return nil, err
 	}
 
-	var inputFormat format
-
-	switch inputF {
-	case "TOML":
-		inputFormat = fTOML
-	case "JSON":
-		inputFormat = fJSON
-	case "YAML":
-		inputFormat = fYAML
-	default:
-		inputFormat = -1
+	data, err = f.Unmarshal(input)
+	if err != nil {
+		return nil, err
 	}
 
-	if inputFormat == -1 {
-		return "", errors.New("Wrong input format: must be TOML, JSON or YAML")
+	data, err = convertMapsToStringMaps(data)
+	if err != nil {
+		return nil, err
 	}
 
-	var outputFormat format
-	switch outputF {
-	case "TOML":
-		outputFormat = fTOML
-	case "JSON":
-		outputFormat = fJSON
-	case "YAML":
-		outputFormat = fYAML
-	default:
-		outputFormat = -1
+	if usesJSONNumber(inputFormat) {
+		data, err = applyNumberMode(data, opts.NumberMode)
+		if err != nil {
+			return nil, err
+		}
 	}
 
-	if outputFormat == -1 {
-		return "", errors.New("Wrong output format: must be TOML, JSON or YAML")
-	}
+	return data, nil
+}
 
-	// Convert the input data from inputFormat to outputFormat.
-	data, err := unmarshal(input, inputFormat)
+// marshal encodes data stored in nested maps and slices in the format named
+// outputFormat, applying the encoding knobs in opts.
+func marshal(data interface{}, outputFormat string, opts Options) (result []byte, err error) {
+	f, err := lookupFormat(outputFormat)
 	if err != nil {
-		return "", err
+		return nil, err
 	}
 
-	indentJSON := true
-	output, err := marshal(data, outputFormat, indentJSON)
+	switch f.(type) {
+	case yamlFormat:
+		if opts.YAMLFlowStyle {
+			result, err = marshalYAMLFlow(data)
+		} else {
+			result, err = yamlFormat{}.Marshal(data)
+		}
+	case tomlFormat:
+		result, err = tomlFormat{}.Marshal(data)
+		if err == nil && opts.TOMLArraysMultiline {
+			result = reformatTOMLArraysMultiline(result)
+		}
+	case xmlFormat:
+		result, err = xmlFormat{sortKeys: opts.SortMapKeys}.Marshal(data)
+	default:
+		result, err = f.Marshal(data)
+	}
 	if err != nil {
-		return "", err
+		return nil, err
+	}
+
+	if opts.IndentJSON && (outputFormat == "JSON" || outputFormat == "JSONC") {
+		indent := opts.IndentString
+		if indent == "" {
+			indent = "  "
+		}
+		buf := new(bytes.Buffer)
+		if err := json.Indent(buf, result, "", indent); err != nil {
+			return nil, err
+		}
+		result = buf.Bytes()
 	}
 
-	return string(output), nil
+	return result, nil
 }
@@ -0,0 +1,193 @@
+package remarshal
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"io"
+
+	"gopkg.in/yaml.v2"
+)
+
+// Decoder reads and decodes a stream of records in a given input format, one
+// at a time, analogous to encoding/json.Decoder. YAML and JSON support more
+// than one document per stream ("---" documents, NDJSON, top-level JSON
+// arrays) and yield one record per Decode call. Formats with no concept of
+// multiple documents (TOML, HCL, XML, CSV, TSV, JSONC) yield a single record
+// followed by io.EOF.
+type Decoder struct {
+	name string
+
+	br      *bufio.Reader
+	jsonDec *json.Decoder
+	yamlDec *yaml.Decoder
+
+	jsonArray        bool
+	jsonArrayStarted bool
+	done             bool
+}
+
+// NewDecoder returns a Decoder that reads successive records in inputFormat
+// from r. inputFormat can be the name of any format registered with
+// RegisterFormat.
+func NewDecoder(r io.Reader, inputFormat string) (*Decoder, error) {
+	f, err := lookupFormat(inputFormat)
+	if err != nil {
+		return nil, err
+	}
+
+	d := &Decoder{name: inputFormat}
+
+	switch f.(type) {
+	case yamlFormat:
+		d.yamlDec = yaml.NewDecoder(r)
+	case jsonFormat:
+		br := bufio.NewReader(r)
+		first, err := peekNonSpace(br)
+		if err != nil && err != io.EOF {
+			return nil, err
+		}
+		d.jsonArray = first == '['
+		d.jsonDec = json.NewDecoder(br)
+		d.jsonDec.UseNumber()
+	default:
+		d.br = bufio.NewReader(r)
+	}
+
+	return d, nil
+}
+
+// Decode reads the next record from the stream into data. It returns io.EOF
+// once there are no more records.
+func (d *Decoder) Decode() (data interface{}, err error) {
+	switch {
+	case d.yamlDec != nil:
+		if err := d.yamlDec.Decode(&data); err != nil {
+			return nil, err
+		}
+		return convertMapsToStringMaps(data)
+	case d.jsonDec != nil:
+		if d.jsonArray {
+			if !d.jsonArrayStarted {
+				if _, err := d.jsonDec.Token(); err != nil {
+					return nil, err
+				}
+				d.jsonArrayStarted = true
+			}
+			if !d.jsonDec.More() {
+				d.jsonDec.Token()
+				return nil, io.EOF
+			}
+		}
+		if err := d.jsonDec.Decode(&data); err != nil {
+			return nil, err
+		}
+		return convertNumbersToInt64(data)
+	default:
+		if d.done {
+			return nil, io.EOF
+		}
+		d.done = true
+
+		b, err := io.ReadAll(d.br)
+		if err != nil {
+			return nil, err
+		}
+		if len(bytes.TrimSpace(b)) == 0 {
+			return nil, io.EOF
+		}
+		return unmarshal(b, d.name, DefaultOptions())
+	}
+}
+
+// Encoder writes a stream of records in a given output format, one at a
+// time, analogous to encoding/json.Encoder.
+type Encoder struct {
+	w     io.Writer
+	name  string
+	opts  Options
+	count int
+}
+
+// NewEncoder returns an Encoder that writes successive records in
+// outputFormat to w using DefaultOptions. outputFormat can be the name of
+// any format registered with RegisterFormat.
+func NewEncoder(w io.Writer, outputFormat string) (*Encoder, error) {
+	if _, err := lookupFormat(outputFormat); err != nil {
+		return nil, err
+	}
+	return &Encoder{w: w, name: outputFormat, opts: DefaultOptions()}, nil
+}
+
+// Encode writes data as the next record in the stream.
+func (e *Encoder) Encode(data interface{}) error {
+	if e.count > 0 {
+		if sep := e.recordSeparator(); sep != "" {
+			if _, err := io.WriteString(e.w, sep); err != nil {
+				return err
+			}
+		}
+	}
+
+	out, err := marshal(data, e.name, e.opts)
+	if err != nil {
+		return err
+	}
+	if _, err := e.w.Write(out); err != nil {
+		return err
+	}
+
+	e.count++
+	return nil
+}
+
+// recordSeparator returns the text written between two successive records,
+// if any.
+func (e *Encoder) recordSeparator() string {
+	switch e.name {
+	case "YAML":
+		return "---\n"
+	case "JSON", "JSONC":
+		return "\n"
+	default:
+		return ""
+	}
+}
+
+// ConvertStream is the streaming counterpart of Convert: it reads every
+// record produced by dec and writes it through enc, stopping at the first
+// error or once dec is exhausted. It's named ConvertStream rather than
+// Convert because Go doesn't allow two functions named Convert in the same
+// package, and Convert(input []byte, inputF, outputF string) already exists.
+// Callers that need to filter or transform records between decoding and
+// encoding can do so by looping over dec.Decode and enc.Encode themselves
+// instead.
+func ConvertStream(dec *Decoder, enc *Encoder) error {
+	for {
+		data, err := dec.Decode()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		if err := enc.Encode(data); err != nil {
+			return err
+		}
+	}
+}
+
+// peekNonSpace returns the first non-whitespace byte in br without
+// consuming anything but leading whitespace.
+func peekNonSpace(br *bufio.Reader) (byte, error) {
+	for {
+		b, err := br.Peek(1)
+		if err != nil {
+			return 0, err
+		}
+		if !isJSONSpace(b[0]) {
+			return b[0], nil
+		}
+		br.Discard(1)
+	}
+}
@@ -0,0 +1,101 @@
+package remarshal
+
+import (
+	"io"
+	"strings"
+	"testing"
+)
+
+func TestDecoderYAMLMultiDoc(t *testing.T) {
+	input := "a: 1\n---\nb: 2\n"
+	dec, err := NewDecoder(strings.NewReader(input), "YAML")
+	if err != nil {
+		t.Fatalf("NewDecoder: %v", err)
+	}
+
+	var got []interface{}
+	for {
+		data, err := dec.Decode()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			t.Fatalf("Decode: %v", err)
+		}
+		got = append(got, data)
+	}
+
+	if len(got) != 2 {
+		t.Fatalf("got %d documents, want 2: %#v", len(got), got)
+	}
+}
+
+func TestDecoderJSONArray(t *testing.T) {
+	input := `[{"a":1},{"b":2}]`
+	dec, err := NewDecoder(strings.NewReader(input), "JSON")
+	if err != nil {
+		t.Fatalf("NewDecoder: %v", err)
+	}
+
+	var got []interface{}
+	for {
+		data, err := dec.Decode()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			t.Fatalf("Decode: %v", err)
+		}
+		got = append(got, data)
+	}
+
+	if len(got) != 2 {
+		t.Fatalf("got %d records, want 2: %#v", len(got), got)
+	}
+}
+
+func TestDecoderNDJSON(t *testing.T) {
+	input := "{\"a\":1}\n{\"a\":2}\n{\"a\":3}\n"
+	dec, err := NewDecoder(strings.NewReader(input), "JSON")
+	if err != nil {
+		t.Fatalf("NewDecoder: %v", err)
+	}
+
+	count := 0
+	for {
+		_, err := dec.Decode()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			t.Fatalf("Decode: %v", err)
+		}
+		count++
+	}
+
+	if count != 3 {
+		t.Fatalf("got %d records, want 3", count)
+	}
+}
+
+func TestConvertStreamYAMLToJSON(t *testing.T) {
+	input := "a: 1\n---\nb: 2\n"
+	dec, err := NewDecoder(strings.NewReader(input), "YAML")
+	if err != nil {
+		t.Fatalf("NewDecoder: %v", err)
+	}
+
+	var out strings.Builder
+	enc, err := NewEncoder(&out, "JSON")
+	if err != nil {
+		t.Fatalf("NewEncoder: %v", err)
+	}
+
+	if err := ConvertStream(dec, enc); err != nil {
+		t.Fatalf("ConvertStream: %v", err)
+	}
+
+	if !strings.Contains(out.String(), `"a": 1`) || !strings.Contains(out.String(), `"b": 2`) {
+		t.Fatalf("unexpected output: %s", out.String())
+	}
+}